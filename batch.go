@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SongRecord is one entry in the discography index, cataloguing a single
+// song regardless of whether it was freshly fetched, resumed from a
+// previous run, or failed.
+type SongRecord struct {
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+	Filename  string `json:"filename,omitempty"`
+	CharCount int    `json:"char_count,omitempty"`
+	Language  string `json:"language,omitempty"`
+	Success   bool   `json:"success"`
+}
+
+// ArtistResult summarizes a single artist's run for aggregation into the
+// top-level index and batch summary.
+type ArtistResult struct {
+	Artist  string       `json:"artist"`
+	Songs   []SongRecord `json:"songs"`
+	Counter Counter      `json:"-"`
+}
+
+// DiscographyIndex is the top-level lyrics/index.json catalog covering
+// every artist processed in a batch run.
+type DiscographyIndex struct {
+	Artists []ArtistResult `json:"artists"`
+}
+
+// Save writes the discography index to lyrics/index.json.
+func (idx *DiscographyIndex) Save() error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+	return os.WriteFile("lyrics/index.json", data, 0644)
+}
+
+// guessLanguage makes a rough language guess from lyric text by counting
+// hits for a handful of common stopwords per language. It is a heuristic,
+// not a real language detector, and falls back to "unknown".
+func guessLanguage(text string) string {
+	if text == "" {
+		return "unknown"
+	}
+
+	lower := strings.ToLower(text)
+	scores := map[string]int{
+		"pt": 0,
+		"en": 0,
+		"es": 0,
+	}
+
+	ptWords := []string{" o ", " a ", " que ", " não ", " de ", " para ", " você "}
+	enWords := []string{" the ", " and ", " you ", " that ", " with ", " for "}
+	esWords := []string{" el ", " la ", " que ", " no ", " de ", " para ", " tú "}
+
+	padded := " " + lower + " "
+	for _, w := range ptWords {
+		scores["pt"] += strings.Count(padded, w)
+	}
+	for _, w := range enWords {
+		scores["en"] += strings.Count(padded, w)
+	}
+	for _, w := range esWords {
+		scores["es"] += strings.Count(padded, w)
+	}
+
+	best := "unknown"
+	bestScore := 0
+	for lang, score := range scores {
+		if score > bestScore {
+			best = lang
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// ResolveArtists determines the list of artists to process, in priority
+// order: the -artists flag, the -artists-file flag, or stdin.
+func ResolveArtists(artistsFlag, artistsFile string, stdin io.Reader) ([]string, error) {
+	if artistsFlag != "" {
+		return splitArtists(artistsFlag), nil
+	}
+
+	if artistsFile != "" {
+		data, err := os.ReadFile(artistsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read artists file: %w", err)
+		}
+		return splitLines(string(data)), nil
+	}
+
+	var artists []string
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			artists = append(artists, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read artists from stdin: %w", err)
+	}
+
+	return artists, nil
+}
+
+func splitArtists(raw string) []string {
+	var artists []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			artists = append(artists, part)
+		}
+	}
+	return artists
+}
+
+func splitLines(raw string) []string {
+	var artists []string
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			artists = append(artists, line)
+		}
+	}
+	return artists
+}
+
+// RunBatch processes each artist sequentially, then writes a top-level
+// discography index once all artists have completed. Each ProcessArtist
+// call starts its own set of s.workerCount workers; workers are not
+// shared across artists, only the scraper's rate limiter and cache are.
+func RunBatch(ctx context.Context, s *Scraper, artists []string) error {
+	index := &DiscographyIndex{}
+	aggregate := Counter{}
+
+	for _, artist := range artists {
+		s.logger.Info("processing artist", "artist", artist)
+
+		result, err := s.ProcessArtist(ctx, artist)
+		if err != nil {
+			s.logger.Error("failed to process artist", "artist", artist, "error", err)
+			continue
+		}
+
+		index.Artists = append(index.Artists, *result)
+		aggregate.Total += result.Counter.Total
+		aggregate.Success += result.Counter.Success
+		aggregate.Error += result.Counter.Error
+		aggregate.Unavailable += result.Counter.Unavailable
+	}
+
+	if err := index.Save(); err != nil {
+		s.logger.Warn("failed to save discography index", "error", err)
+	}
+
+	s.logger.Info("batch completed",
+		"artists", len(artists),
+		"total", aggregate.Total,
+		"success", aggregate.Success,
+		"error", aggregate.Error,
+		"unavailable", aggregate.Unavailable,
+	)
+
+	return nil
+}
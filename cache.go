@@ -0,0 +1,100 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is a simple on-disk cache keyed by the hash of the request URL.
+// It is safe for concurrent use by multiple workers.
+type Cache struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool
+	hits    int64
+	misses  int64
+}
+
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Body      string    `json:"body"`
+}
+
+// NewCache creates a cache rooted at dir, creating it if necessary.
+func NewCache(dir string, ttl time.Duration, refresh bool) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &Cache{dir: dir, ttl: ttl, refresh: refresh}, nil
+}
+
+// DefaultCacheDir returns ~/.cache/cli-lyric-exporter, falling back to a
+// relative directory if the user cache dir can't be determined.
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ".cache/cli-lyric-exporter"
+	}
+	return filepath.Join(dir, "cli-lyric-exporter")
+}
+
+func (c *Cache) keyFor(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) path(url string) string {
+	return filepath.Join(c.dir, c.keyFor(url)+".json")
+}
+
+// Get returns the cached body for url if present and within the TTL.
+func (c *Cache) Get(url string) (string, bool) {
+	if c.refresh {
+		atomic.AddInt64(&c.misses, 1)
+		return "", false
+	}
+
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		atomic.AddInt64(&c.misses, 1)
+		return "", false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		atomic.AddInt64(&c.misses, 1)
+		return "", false
+	}
+
+	if time.Since(entry.FetchedAt) > c.ttl {
+		atomic.AddInt64(&c.misses, 1)
+		return "", false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return entry.Body, true
+}
+
+// Set stores body for url, stamped with the current time.
+func (c *Cache) Set(url, body string) error {
+	entry := cacheEntry{FetchedAt: time.Now(), Body: body}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	return os.WriteFile(c.path(url), data, 0644)
+}
+
+// Stats returns the number of cache hits and misses observed so far.
+func (c *Cache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -13,72 +18,185 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/schollz/progressbar/v3"
+	"golang.org/x/time/rate"
 )
 
 type Song struct {
 	Title  string
 	URL    string
 	Lyrics string
+	Synced []LyricLine
 	Error  error
 }
 
 type Scraper struct {
 	baseURL      string
 	workerCount  int
-	debug        bool
-	logger       *log.Logger
+	logger       *slog.Logger
 	client       *http.Client
 	maxRetries   int
 	retryBackoff time.Duration
+	provider     string
+	lrclib       *LRCLibProvider
+	cache        *Cache
+	retryFailed  bool
+	limiter      *rate.Limiter
+	robots       *RobotsPolicy
+	formats      map[string]bool
 }
 
 type ScraperConfig struct {
 	WorkerCount  int
-	Debug        bool
+	Logger       *slog.Logger
 	MaxRetries   int
 	RetryBackoff time.Duration
+	Provider     string
+	Cache        *Cache
+	RetryFailed  bool
+	RPS          float64
+	Burst        int
+	Robots       *RobotsPolicy
+	Formats      []string
 }
 
 func NewScraper(config ScraperConfig) *Scraper {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	provider := config.Provider
+	if provider == "" {
+		provider = "letras"
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	rps := config.RPS
+	if rps <= 0 {
+		rps = 1
+	}
+	burst := config.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+
+	formats := config.Formats
+	if len(formats) == 0 {
+		formats = []string{"txt", "combined"}
+	}
+	formatSet := make(map[string]bool, len(formats))
+	for _, format := range formats {
+		formatSet[strings.TrimSpace(format)] = true
+	}
+
 	return &Scraper{
 		baseURL:      "https://letras.mus.br",
 		workerCount:  config.WorkerCount,
-		debug:        config.Debug,
-		logger:       log.New(os.Stdout, "[SCRAPER] ", log.Ltime),
+		logger:       logger,
 		maxRetries:   config.MaxRetries,
 		retryBackoff: config.RetryBackoff,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		provider:     provider,
+		lrclib:       NewLRCLibProvider(client, limiter),
+		cache:        config.Cache,
+		retryFailed:  config.RetryFailed,
+		limiter:      limiter,
+		robots:       config.Robots,
+		formats:      formatSet,
+		client:       client,
 	}
 }
 
-func (s *Scraper) debugLog(format string, v ...interface{}) {
-	if s.debug {
-		s.logger.Printf(format, v...)
+// fetchBody returns the response body for url, serving from cache when a
+// fresh entry exists and storing freshly fetched bodies back into it.
+func (s *Scraper) fetchBody(ctx context.Context, rawURL string) (string, error) {
+	if s.cache != nil {
+		if body, ok := s.cache.Get(rawURL); ok {
+			s.logger.Debug("cache hit", "url", rawURL)
+			return body, nil
+		}
+	}
+
+	if parsed, err := url.Parse(rawURL); err == nil && !s.robots.Allowed(parsed.Path) {
+		return "", fmt.Errorf("disallowed by robots.txt: %s", parsed.Path)
+	}
+
+	if err := s.limiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limiter wait: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return "", throttledStatusError(resp)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	body := string(data)
+
+	if s.cache != nil {
+		if err := s.cache.Set(rawURL, body); err != nil {
+			s.logger.Warn("failed to cache response", "url", rawURL, "error", err)
+		}
 	}
+
+	return body, nil
 }
 
-func (s *Scraper) retryOperation(ctx context.Context, operation string, fn func() error) error {
+// retryOperation runs fn with jittered exponential backoff, logging each
+// attempt with the caller-supplied context fields (e.g. artist, title,
+// url) rather than a single pre-formatted message string.
+func (s *Scraper) retryOperation(ctx context.Context, action string, fields []any, fn func() error) error {
 	var err error
 	backoff := s.retryBackoff
 
 	for retry := 0; retry <= s.maxRetries; retry++ {
 		if retry > 0 {
-			s.debugLog("Retrying %s (attempt %d/%d) after %v", operation, retry, s.maxRetries, backoff)
+			wait := backoff
+
+			var retryAfter *RetryAfterError
+			if errors.As(err, &retryAfter) {
+				wait = retryAfter.After
+			}
+
+			args := append([]any{"action", action, "attempt", retry, "max_retries", s.maxRetries, "wait", wait}, fields...)
+			s.logger.Debug("retrying operation", args...)
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(backoff):
+			case <-time.After(wait):
 			}
-			backoff *= 2 // Exponential backoff
+
+			// Exponential backoff with jitter to avoid thundering-herd retries.
+			backoff = backoff*2 + time.Duration(rand.Int63n(int64(backoff)+1))
 		}
 
+		start := time.Now()
 		if err = fn(); err == nil {
 			return nil
 		}
 
-		s.debugLog("Error in %s (attempt %d/%d): %v", operation, retry+1, s.maxRetries, err)
+		args := append([]any{"action", action, "attempt", retry + 1, "max_retries", s.maxRetries, "latency", time.Since(start), "error", err}, fields...)
+		s.logger.Debug("operation failed", args...)
 	}
 
 	return fmt.Errorf("failed after %d retries: %w", s.maxRetries, err)
@@ -87,22 +205,12 @@ func (s *Scraper) retryOperation(ctx context.Context, operation string, fn func(
 func (s *Scraper) getSongList(ctx context.Context, artist string) ([]Song, error) {
 	url := fmt.Sprintf("%s/%s", s.baseURL, artist)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := s.client.Do(req)
+	body, err := s.fetchBody(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch artist page: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
@@ -154,22 +262,12 @@ func (s *Scraper) getSongList(ctx context.Context, artist string) ([]Song, error
 }
 
 func (s *Scraper) getLyrics(ctx context.Context, url string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := s.client.Do(req)
+	body, err := s.fetchBody(ctx, url)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch lyrics page: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
 
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse HTML: %w", err)
 	}
@@ -216,11 +314,58 @@ func (s *Scraper) saveLyrics(artist string, song Song) error {
 		return fmt.Errorf("no lyrics to save")
 	}
 
-	formattedLyrics := s.formatLyrics(song.Lyrics)
-	content := fmt.Sprintf("Title: %s\nArtist: %s\n\n%s\n", song.Title, artist, formattedLyrics)
+	if s.formats["txt"] {
+		formattedLyrics := s.formatLyrics(song.Lyrics)
+		content := fmt.Sprintf("Title: %s\nArtist: %s\n\n%s\n", song.Title, artist, formattedLyrics)
+
+		filename := fmt.Sprintf("lyrics/%s/%s.txt", artist, sanitizeFilename(song.Title))
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+
+	if s.formats["lrc"] && len(song.Synced) > 0 {
+		if err := s.saveLRC(artist, song); err != nil {
+			return fmt.Errorf("failed to save LRC file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// saveLRC writes a standard LRC file for the song, using per-word
+// timestamps to emit enhanced LRC tags when the provider supplied them.
+func (s *Scraper) saveLRC(artist string, song Song) error {
+	if len(song.Synced) == 0 {
+		return fmt.Errorf("no synced lyrics to save")
+	}
+
+	var content strings.Builder
+	content.WriteString(fmt.Sprintf("[ti:%s]\n", song.Title))
+	content.WriteString(fmt.Sprintf("[ar:%s]\n", artist))
+
+	for _, line := range song.Synced {
+		content.WriteString(fmt.Sprintf("[%s]", formatLRCTimestamp(line.Timestamp)))
+		if len(line.Words) > 0 {
+			for _, word := range line.Words {
+				content.WriteString(fmt.Sprintf("<%s>%s ", formatLRCTimestamp(word.Timestamp), word.Text))
+			}
+		} else {
+			content.WriteString(line.Text)
+		}
+		content.WriteString("\n")
+	}
+
+	filename := fmt.Sprintf("lyrics/%s/%s.lrc", artist, sanitizeFilename(song.Title))
+	return os.WriteFile(filename, []byte(content.String()), 0644)
+}
 
-	filename := fmt.Sprintf("lyrics/%s/%s.txt", artist, sanitizeFilename(song.Title))
-	return os.WriteFile(filename, []byte(content), 0644)
+// formatLRCTimestamp renders a duration as "mm:ss.xx" for LRC tags.
+func formatLRCTimestamp(d time.Duration) string {
+	minutes := int(d / time.Minute)
+	seconds := int((d % time.Minute) / time.Second)
+	hundredths := int((d % time.Second) / (10 * time.Millisecond))
+	return fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, hundredths)
 }
 
 func (s *Scraper) saveAllLyrics(artist string, songs []Song) error {
@@ -262,21 +407,52 @@ func (s *Scraper) saveLLMFormat(artist string, songs []Song) error {
 	return os.WriteFile(filename, []byte(content.String()), 0644)
 }
 
-func (s *Scraper) ProcessArtist(ctx context.Context, artist string) error {
+func (s *Scraper) ProcessArtist(ctx context.Context, artist string) (*ArtistResult, error) {
 	outputDir := fmt.Sprintf("lyrics/%s", artist)
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	s.debugLog("Starting to fetch song list for artist: %s", artist)
+	s.logger.Debug("fetching song list", "artist", artist)
 	songs, err := s.getSongList(ctx, artist)
 	if err != nil {
-		return fmt.Errorf("failed to get song list: %w", err)
+		return nil, fmt.Errorf("failed to get song list: %w", err)
+	}
+	s.logger.Info("found songs", "artist", artist, "count", len(songs))
+
+	manifest, err := LoadManifest(artist)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	var toProcess []Song
+	var skippedSongs []Song
+
+	for _, song := range songs {
+		entry, known := manifest.Songs[song.URL]
+
+		if known && entry.Status == StatusSuccess {
+			song.Lyrics = entry.Lyrics
+			skippedSongs = append(skippedSongs, song)
+			continue
+		}
+
+		if s.retryFailed {
+			// Only retry songs the manifest already marked failed; leave
+			// songs it has never seen for a normal (non -retry-failed) run.
+			if known && entry.Status == StatusFailed {
+				toProcess = append(toProcess, song)
+			}
+			continue
+		}
+
+		toProcess = append(toProcess, song)
 	}
-	s.debugLog("Found %d songs for artist %s", len(songs), artist)
+
+	s.logger.Info("resuming previous run", "artist", artist, "to_process", len(toProcess), "skipped", len(skippedSongs))
 
 	// Initialize progress bar
-	bar := progressbar.NewOptions(len(songs),
+	bar := progressbar.NewOptions(len(toProcess),
 		progressbar.OptionEnableColorCodes(true),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(15),
@@ -289,22 +465,22 @@ func (s *Scraper) ProcessArtist(ctx context.Context, artist string) error {
 			BarEnd:        "]",
 		}))
 
-	results := make(chan Song, len(songs))
+	results := make(chan Song, len(toProcess))
 	var wg sync.WaitGroup
-	jobs := make(chan Song, len(songs))
+	jobs := make(chan Song, len(toProcess))
 
 	// Start workers
 	for i := 0; i < s.workerCount; i++ {
 		wg.Add(1)
-		go s.worker(ctx, i, jobs, results, &wg, bar)
+		go s.worker(ctx, i, artist, jobs, results, &wg, bar)
 	}
 
 	// Send jobs to workers
 	go func() {
-		for _, song := range songs {
+		for _, song := range toProcess {
 			select {
 			case jobs <- song:
-				s.debugLog("Queued song: %s", song.Title)
+				s.logger.Debug("queued song", "artist", artist, "title", song.Title)
 			case <-ctx.Done():
 				return
 			}
@@ -321,7 +497,7 @@ func (s *Scraper) ProcessArtist(ctx context.Context, artist string) error {
 	var failedSongs []Song
 
 	// Initialize saving progress bar
-	saveBar := progressbar.NewOptions(len(songs),
+	saveBar := progressbar.NewOptions(len(toProcess),
 		progressbar.OptionEnableColorCodes(true),
 		progressbar.OptionShowCount(),
 		progressbar.OptionSetWidth(15),
@@ -336,61 +512,126 @@ func (s *Scraper) ProcessArtist(ctx context.Context, artist string) error {
 
 	for song := range results {
 		if song.Error != nil {
-			s.logger.Printf("Error processing %s: %v", song.Title, song.Error)
+			s.logger.Error("failed to process song", "artist", artist, "title", song.Title, "error", song.Error)
 			failedSongs = append(failedSongs, song)
 			saveBar.Add(1)
 			continue
 		}
 
-		err := s.retryOperation(ctx, fmt.Sprintf("saving %s", song.Title), func() error {
+		err := s.retryOperation(ctx, "saving", []any{"artist", artist, "title", song.Title, "url", song.URL}, func() error {
 			return s.saveLyrics(artist, song)
 		})
 
 		if err != nil {
-			s.logger.Printf("Error saving %s: %v", song.Title, err)
+			s.logger.Error("failed to save song", "artist", artist, "title", song.Title, "error", err)
 			failedSongs = append(failedSongs, song)
 		} else {
 			processedSongs = append(processedSongs, song)
-			s.debugLog("Successfully processed: %s", song.Title)
+			s.logger.Debug("successfully processed song", "artist", artist, "title", song.Title)
 		}
 		saveBar.Add(1)
 	}
 
 	fmt.Println() // New line after progress bars
 
-	if err := s.saveAllLyrics(artist, processedSongs); err != nil {
-		return fmt.Errorf("failed to save combined lyrics: %w", err)
+	for _, song := range processedSongs {
+		manifest.Record(song, StatusSuccess)
+	}
+	for _, song := range failedSongs {
+		manifest.Record(song, StatusFailed)
 	}
+	if err := manifest.Save(); err != nil {
+		s.logger.Warn("failed to save manifest", "artist", artist, "error", err)
+	}
+
+	// The combined/LLM/dataset outputs are rebuilt from every successful
+	// song, not just the ones fetched this run, so resuming doesn't
+	// truncate them down to whatever was newly processed.
+	allSuccessful := make([]Song, 0, len(processedSongs)+len(skippedSongs))
+	allSuccessful = append(allSuccessful, processedSongs...)
+	allSuccessful = append(allSuccessful, skippedSongs...)
 
-	// Ask user if they want to save in LLM format
-	var response string
-	fmt.Print("\nWould you like to save all lyrics in a single file optimized for LLM ingestion? (y/N): ")
-	fmt.Scanln(&response)
+	if s.formats["combined"] {
+		if err := s.saveAllLyrics(artist, allSuccessful); err != nil {
+			return nil, fmt.Errorf("failed to save combined lyrics: %w", err)
+		}
+	}
 
-	if strings.ToLower(response) == "y" || strings.ToLower(response) == "yes" {
-		if err := s.saveLLMFormat(artist, processedSongs); err != nil {
-			s.logger.Printf("Warning: Failed to save LLM format: %v", err)
+	if s.formats["llm"] {
+		if err := s.saveLLMFormat(artist, allSuccessful); err != nil {
+			s.logger.Warn("failed to save LLM format", "artist", artist, "error", err)
 		} else {
-			s.logger.Printf("Successfully saved LLM format file")
+			s.logger.Info("saved LLM format file", "artist", artist)
 		}
 	}
 
-	s.logger.Printf("\nScraping completed:")
-	s.logger.Printf("- Total songs: %d", len(songs))
-	s.logger.Printf("- Successfully processed: %d", len(processedSongs))
-	s.logger.Printf("- Failed: %d", len(failedSongs))
+	if s.formats["jsonl"] {
+		if err := s.saveJSONL(artist, allSuccessful); err != nil {
+			s.logger.Warn("failed to save JSONL dataset", "artist", artist, "error", err)
+		} else {
+			s.logger.Info("saved JSONL dataset file", "artist", artist)
+		}
+	}
 
-	if len(failedSongs) > 0 {
-		s.logger.Println("\nFailed songs:")
-		for _, song := range failedSongs {
-			s.logger.Printf("- %s: %v", song.Title, song.Error)
+	if s.formats["parquet"] {
+		if err := s.saveParquet(artist, allSuccessful); err != nil {
+			s.logger.Warn("failed to save parquet dataset", "artist", artist, "error", err)
+		} else {
+			s.logger.Info("saved parquet dataset file", "artist", artist)
 		}
 	}
 
-	return nil
+	counter := Counter{Total: len(songs), Success: len(processedSongs) + len(skippedSongs)}
+	for _, song := range failedSongs {
+		if classifyFailure(song.Error) {
+			counter.Unavailable++
+		} else {
+			counter.Error++
+		}
+	}
+
+	s.logger.Info("scraping completed",
+		"artist", artist,
+		"total", counter.Total,
+		"success", counter.Success,
+		"error", counter.Error,
+		"unavailable", counter.Unavailable,
+		"skipped", len(skippedSongs),
+	)
+
+	if s.cache != nil {
+		hits, misses := s.cache.Stats()
+		s.logger.Info("cache stats", "artist", artist, "hits", hits, "misses", misses)
+	}
+
+	for _, song := range failedSongs {
+		s.logger.Warn("song failed", "artist", artist, "title", song.Title, "error", song.Error)
+	}
+
+	result := &ArtistResult{Artist: artist, Counter: counter}
+
+	for _, song := range allSuccessful {
+		result.Songs = append(result.Songs, SongRecord{
+			Title:     song.Title,
+			URL:       song.URL,
+			Filename:  s.songFilename(artist, song),
+			CharCount: len(song.Lyrics),
+			Language:  guessLanguage(song.Lyrics),
+			Success:   true,
+		})
+	}
+	for _, song := range failedSongs {
+		result.Songs = append(result.Songs, SongRecord{
+			Title:   song.Title,
+			URL:     song.URL,
+			Success: false,
+		})
+	}
+
+	return result, nil
 }
 
-func (s *Scraper) worker(ctx context.Context, id int, jobs <-chan Song, results chan<- Song, wg *sync.WaitGroup, bar *progressbar.ProgressBar) {
+func (s *Scraper) worker(ctx context.Context, id int, artist string, jobs <-chan Song, results chan<- Song, wg *sync.WaitGroup, bar *progressbar.ProgressBar) {
 	defer wg.Done()
 
 	for song := range jobs {
@@ -398,9 +639,19 @@ func (s *Scraper) worker(ctx context.Context, id int, jobs <-chan Song, results
 		case <-ctx.Done():
 			return
 		default:
-			s.debugLog("Worker %d processing: %s", id, song.Title)
+			s.logger.Debug("worker processing song", "worker_id", id, "artist", artist, "title", song.Title, "url", song.URL)
+
+			err := s.retryOperation(ctx, "downloading", []any{"artist", artist, "title", song.Title, "url", song.URL}, func() error {
+				if s.provider == "lrclib" {
+					lyrics, err := s.lrclib.Fetch(ctx, artist, song.Title)
+					if err != nil {
+						return err
+					}
+					song.Lyrics = lyrics.PlainText
+					song.Synced = lyrics.Synced
+					return nil
+				}
 
-			err := s.retryOperation(ctx, fmt.Sprintf("downloading %s", song.Title), func() error {
 				lyrics, err := s.getLyrics(ctx, song.URL)
 				if err != nil {
 					return err
@@ -415,13 +666,19 @@ func (s *Scraper) worker(ctx context.Context, id int, jobs <-chan Song, results
 
 			results <- song
 			bar.Add(1)
-
-			// Be polite to the server
-			time.Sleep(time.Second)
 		}
 	}
 }
 
+// songFilename returns the path a song's plain-text lyrics were written
+// to, or "" when the txt format isn't enabled and no such file exists.
+func (s *Scraper) songFilename(artist string, song Song) string {
+	if !s.formats["txt"] {
+		return ""
+	}
+	return fmt.Sprintf("lyrics/%s/%s.txt", artist, sanitizeFilename(song.Title))
+}
+
 func sanitizeFilename(filename string) string {
 	invalid := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|"}
 	result := filename
@@ -436,28 +693,81 @@ func sanitizeFilename(filename string) string {
 func main() {
 	// Command line flags
 	workerCount := flag.Int("workers", 5, "Number of concurrent workers")
-	debug := flag.Bool("debug", false, "Enable debug logging")
+	logLevel := flag.String("log-level", "info", "Log level: debug|info|warn|error")
+	logFormat := flag.String("log-format", "text", "Log format: text|json")
 	maxRetries := flag.Int("retries", 3, "Maximum number of retries per request")
 	retryBackoff := flag.Duration("backoff", 2*time.Second, "Initial retry backoff duration")
+	provider := flag.String("provider", "letras", "Lyrics provider to use: letras|lrclib")
+	cacheTTL := flag.Duration("cache-ttl", 7*24*time.Hour, "How long cached pages/lyrics remain fresh")
+	refresh := flag.Bool("refresh", false, "Bypass the cache and re-fetch everything")
+	retryFailed := flag.Bool("retry-failed", false, "Only re-process songs marked failed in a previous run's manifest")
+	rps := flag.Float64("rps", 1, "Maximum requests per second shared across all workers")
+	burst := flag.Int("burst", 1, "Burst size for the request rate limiter")
+	artistsFlag := flag.String("artists", "", "Comma-separated list of artists to process")
+	artistsFile := flag.String("artists-file", "", "Path to a file with one artist per line")
+	formatFlag := flag.String("format", "txt,combined", "Comma-separated output formats: txt,combined,llm,jsonl,parquet,lrc")
 	flag.Parse()
 
+	if *provider != "letras" && *provider != "lrclib" {
+		log.Fatalf("unknown provider %q: must be letras or lrclib", *provider)
+	}
+
+	logger, err := NewLogger(*logLevel, *logFormat)
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	cache, err := NewCache(DefaultCacheDir(), *cacheTTL, *refresh)
+	if err != nil {
+		log.Fatalf("failed to initialize cache: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	robotsBaseURL := "https://letras.mus.br"
+	if *provider == "lrclib" {
+		robotsBaseURL = "https://lrclib.net"
+	}
+
+	robots, err := FetchRobotsPolicy(ctx, &http.Client{Timeout: 10 * time.Second}, robotsBaseURL)
+	if err != nil {
+		logger.Warn("failed to fetch robots.txt, proceeding without it", "error", err)
+		robots = &RobotsPolicy{}
+	}
+
+	if robots.CrawlDelay > 0 {
+		if crawlDelayRPS := 1 / robots.CrawlDelay.Seconds(); crawlDelayRPS < *rps {
+			logger.Info("lowering request rate to honor robots.txt Crawl-delay", "crawl_delay", robots.CrawlDelay, "rps", crawlDelayRPS)
+			*rps = crawlDelayRPS
+		}
+	}
+
 	config := ScraperConfig{
 		WorkerCount:  *workerCount,
-		Debug:        *debug,
+		Logger:       logger,
 		MaxRetries:   *maxRetries,
 		RetryBackoff: *retryBackoff,
+		Provider:     *provider,
+		Cache:        cache,
+		RetryFailed:  *retryFailed,
+		RPS:          *rps,
+		Burst:        *burst,
+		Robots:       robots,
+		Formats:      strings.Split(*formatFlag, ","),
 	}
 
 	scraper := NewScraper(config)
 
-	var artist string
-	fmt.Print("Enter artist name (as it appears in the URL): ")
-	fmt.Scanln(&artist)
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	artists, err := ResolveArtists(*artistsFlag, *artistsFile, os.Stdin)
+	if err != nil {
+		log.Fatalf("failed to resolve artists: %v", err)
+	}
+	if len(artists) == 0 {
+		log.Fatal("no artists given: use -artists, -artists-file, or pipe artist names on stdin")
+	}
 
-	if err := scraper.ProcessArtist(ctx, artist); err != nil {
+	if err := RunBatch(ctx, scraper, artists); err != nil {
 		log.Fatal(err)
 	}
 }
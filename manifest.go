@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SongStatus is the outcome recorded for a song in a Manifest.
+type SongStatus string
+
+const (
+	StatusSuccess SongStatus = "success"
+	StatusFailed  SongStatus = "failed"
+	StatusSkipped SongStatus = "skipped"
+)
+
+// ManifestEntry records the last known outcome for a single song, keyed
+// by URL in its parent Manifest (song titles are not unique — an artist
+// page can list distinct live/remastered versions under the same title).
+type ManifestEntry struct {
+	URL       string     `json:"url"`
+	Status    SongStatus `json:"status"`
+	Timestamp time.Time  `json:"timestamp"`
+	Error     string     `json:"error,omitempty"`
+	Lyrics    string     `json:"lyrics,omitempty"`
+}
+
+// Manifest tracks per-song progress for an artist across runs, enabling
+// ProcessArtist to resume a long-running discography export.
+type Manifest struct {
+	Artist string                   `json:"artist"`
+	Songs  map[string]ManifestEntry `json:"songs"`
+}
+
+// manifestPath returns the path to an artist's manifest file.
+func manifestPath(artist string) string {
+	return filepath.Join("lyrics", artist, ".manifest.json")
+}
+
+// LoadManifest reads an artist's manifest from disk, returning an empty
+// manifest (not an error) if none exists yet.
+func LoadManifest(artist string) (*Manifest, error) {
+	data, err := os.ReadFile(manifestPath(artist))
+	if os.IsNotExist(err) {
+		return &Manifest{Artist: artist, Songs: make(map[string]ManifestEntry)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m.Songs == nil {
+		m.Songs = make(map[string]ManifestEntry)
+	}
+
+	return &m, nil
+}
+
+// Save writes the manifest back to lyrics/<artist>/.manifest.json.
+func (m *Manifest) Save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(manifestPath(m.Artist), data, 0644)
+}
+
+// Record updates the manifest entry for a song after a processing attempt.
+// The lyrics text is persisted alongside success entries so a later resumed
+// run can merge them back into the combined/LLM/dataset outputs without
+// re-fetching.
+func (m *Manifest) Record(song Song, status SongStatus) {
+	entry := ManifestEntry{
+		URL:       song.URL,
+		Status:    status,
+		Timestamp: time.Now(),
+	}
+	if song.Error != nil {
+		entry.Error = song.Error.Error()
+	}
+	if status == StatusSuccess {
+		entry.Lyrics = song.Lyrics
+	}
+	m.Songs[song.URL] = entry
+}
+
+// Counter tallies outcomes across a run, mirroring the breakdown printed
+// in the final summary.
+type Counter struct {
+	Total       int
+	Success     int
+	Error       int
+	Unavailable int
+}
+
+// classifyFailure reports whether err is a "not found" style error, which
+// the run summary counts as Unavailable rather than Error. It returns false
+// for nil and for transient/network errors. Both kinds are still recorded
+// as StatusFailed in the manifest.
+func classifyFailure(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no lyrics found")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil error", err: nil, want: false},
+		{name: "not found on lrclib", err: errors.New("no lyrics found on lrclib"), want: true},
+		{name: "transient network error", err: errors.New("connection reset by peer"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := classifyFailure(tt.err); got != tt.want {
+			t.Errorf("%s: classifyFailure(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestManifestRecordKeyedByURL(t *testing.T) {
+	dir := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join("lyrics", "Some Artist"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadManifest("Some Artist")
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+
+	// Two distinct songs (different URLs) can share a display title, e.g.
+	// a live version under a separate song page.
+	studio := Song{Title: "Intro", URL: "https://example.com/intro-studio", Lyrics: "studio lyrics"}
+	live := Song{Title: "Intro", URL: "https://example.com/intro-live", Error: errors.New("no lyrics found on lrclib")}
+
+	m.Record(studio, StatusSuccess)
+	m.Record(live, StatusFailed)
+
+	if len(m.Songs) != 2 {
+		t.Fatalf("expected 2 distinct manifest entries for same-titled songs, got %d: %+v", len(m.Songs), m.Songs)
+	}
+
+	if err := m.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	reloaded, err := LoadManifest("Some Artist")
+	if err != nil {
+		t.Fatalf("LoadManifest (reload): %v", err)
+	}
+
+	studioEntry, ok := reloaded.Songs[studio.URL]
+	if !ok {
+		t.Fatalf("missing entry for studio URL %q", studio.URL)
+	}
+	if studioEntry.Status != StatusSuccess || studioEntry.Lyrics != "studio lyrics" {
+		t.Errorf("studio entry = %+v, want success with persisted lyrics", studioEntry)
+	}
+
+	liveEntry, ok := reloaded.Songs[live.URL]
+	if !ok {
+		t.Fatalf("missing entry for live URL %q", live.URL)
+	}
+	if liveEntry.Status != StatusFailed || liveEntry.Lyrics != "" {
+		t.Errorf("live entry = %+v, want failed with no lyrics", liveEntry)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// datasetRecord is the common schema shared by the JSONL and Parquet
+// writers, suitable for ingestion into HuggingFace datasets or a vector
+// DB pipeline.
+type datasetRecord struct {
+	Artist    string `json:"artist" parquet:"name=artist, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Title     string `json:"title" parquet:"name=title, type=BYTE_ARRAY, convertedtype=UTF8"`
+	URL       string `json:"url" parquet:"name=url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Lyrics    string `json:"lyrics" parquet:"name=lyrics, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Language  string `json:"language" parquet:"name=language, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScrapedAt string `json:"scraped_at" parquet:"name=scraped_at, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+func buildDatasetRecords(artist string, songs []Song, scrapedAt time.Time) []datasetRecord {
+	records := make([]datasetRecord, 0, len(songs))
+	for _, song := range songs {
+		records = append(records, datasetRecord{
+			Artist:    artist,
+			Title:     song.Title,
+			URL:       song.URL,
+			Lyrics:    song.Lyrics,
+			Language:  guessLanguage(song.Lyrics),
+			ScrapedAt: scrapedAt.Format(time.RFC3339),
+		})
+	}
+	return records
+}
+
+// saveJSONL writes one JSON object per line, one per song, for
+// downstream dataset and vector-DB ingestion.
+func (s *Scraper) saveJSONL(artist string, songs []Song) error {
+	if len(songs) == 0 {
+		return fmt.Errorf("no songs to save")
+	}
+
+	filename := fmt.Sprintf("lyrics/%s/%s.jsonl", artist, sanitizeFilename(artist))
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create jsonl file: %w", err)
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	for _, record := range buildDatasetRecords(artist, songs, time.Now()) {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode jsonl record: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// saveParquet writes the same dataset schema as saveJSONL to a Parquet
+// file for columnar, analytics-friendly ingestion.
+func (s *Scraper) saveParquet(artist string, songs []Song) error {
+	if len(songs) == 0 {
+		return fmt.Errorf("no songs to save")
+	}
+
+	filename := fmt.Sprintf("lyrics/%s/%s.parquet", artist, sanitizeFilename(artist))
+
+	fw, err := local.NewLocalFileWriter(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create parquet file: %w", err)
+	}
+	defer fw.Close()
+
+	pw, err := writer.NewParquetWriter(fw, new(datasetRecord), 4)
+	if err != nil {
+		return fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	for _, record := range buildDatasetRecords(artist, songs, time.Now()) {
+		if err := pw.Write(record); err != nil {
+			return fmt.Errorf("failed to write parquet record: %w", err)
+		}
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		return fmt.Errorf("failed to finalize parquet file: %w", err)
+	}
+
+	return nil
+}
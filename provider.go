@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// LyricLine is a single time-synced line of lyrics, optionally carrying
+// per-word timestamps when the provider supports enhanced LRC.
+type LyricLine struct {
+	Timestamp time.Duration
+	Text      string
+	Words     []WordTimestamp
+}
+
+// WordTimestamp marks the start of a single word within a LyricLine, used
+// for enhanced (word-level) LRC output.
+type WordTimestamp struct {
+	Timestamp time.Duration
+	Text      string
+}
+
+// Lyrics holds both the plain-text lyrics and, when available, the
+// time-synced representation returned by providers like LRCLIB.
+type Lyrics struct {
+	PlainText string
+	Synced    []LyricLine
+}
+
+// LRCLibProvider queries the public LRCLIB API for time-synced lyrics.
+type LRCLibProvider struct {
+	baseURL string
+	client  *http.Client
+	limiter *rate.Limiter
+}
+
+func NewLRCLibProvider(client *http.Client, limiter *rate.Limiter) *LRCLibProvider {
+	return &LRCLibProvider{
+		baseURL: "https://lrclib.net/api/get",
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+type lrclibResponse struct {
+	PlainLyrics  string `json:"plainLyrics"`
+	SyncedLyrics string `json:"syncedLyrics"`
+}
+
+func (p *LRCLibProvider) Fetch(ctx context.Context, artist, title string) (Lyrics, error) {
+	reqURL := fmt.Sprintf("%s?artist_name=%s&track_name=%s", p.baseURL, url.QueryEscape(artist), url.QueryEscape(title))
+
+	if p.limiter != nil {
+		if err := p.limiter.Wait(ctx); err != nil {
+			return Lyrics{}, fmt.Errorf("rate limiter wait: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Lyrics{}, fmt.Errorf("failed to fetch from lrclib: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return Lyrics{}, throttledStatusError(resp)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Lyrics{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var parsed lrclibResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Lyrics{}, fmt.Errorf("failed to parse lrclib response: %w", err)
+	}
+
+	if parsed.PlainLyrics == "" && parsed.SyncedLyrics == "" {
+		return Lyrics{}, fmt.Errorf("no lyrics found on lrclib")
+	}
+
+	lyrics := Lyrics{PlainText: parsed.PlainLyrics}
+	if parsed.SyncedLyrics != "" {
+		lyrics.Synced = parseLRC(parsed.SyncedLyrics)
+		if lyrics.PlainText == "" {
+			lyrics.PlainText = syncedToPlainText(lyrics.Synced)
+		}
+	}
+
+	return lyrics, nil
+}
+
+// parseLRC parses standard [mm:ss.xx] tagged lines into LyricLines. Lines
+// without a recognizable timestamp tag are skipped.
+func parseLRC(raw string) []LyricLine {
+	var lines []LyricLine
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(line, "[") {
+			continue
+		}
+		end := strings.Index(line, "]")
+		if end < 0 {
+			continue
+		}
+		ts, err := parseLRCTimestamp(line[1:end])
+		if err != nil {
+			continue
+		}
+		lines = append(lines, LyricLine{
+			Timestamp: ts,
+			Text:      strings.TrimSpace(line[end+1:]),
+		})
+	}
+	return lines
+}
+
+// parseLRCTimestamp parses a "mm:ss.xx" tag into a duration.
+func parseLRCTimestamp(tag string) (time.Duration, error) {
+	var minutes, seconds, hundredths int
+	if _, err := fmt.Sscanf(tag, "%d:%d.%d", &minutes, &seconds, &hundredths); err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q: %w", tag, err)
+	}
+	return time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(hundredths)*10*time.Millisecond, nil
+}
+
+func syncedToPlainText(lines []LyricLine) string {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line.Text)
+		b.WriteString("\n")
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLRCTimestamp(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{tag: "00:00.00", want: 0},
+		{tag: "00:12.50", want: 12*time.Second + 500*time.Millisecond},
+		{tag: "01:02.34", want: time.Minute + 2*time.Second + 340*time.Millisecond},
+		{tag: "not a timestamp", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLRCTimestamp(tt.tag)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseLRCTimestamp(%q): expected error, got nil", tt.tag)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLRCTimestamp(%q): unexpected error: %v", tt.tag, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLRCTimestamp(%q) = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestParseLRC(t *testing.T) {
+	raw := "[00:00.00] \n[00:12.50]Hello there\r\nnot a lyric line\n[01:02.34]Second line"
+
+	lines := parseLRC(raw)
+	if len(lines) != 3 {
+		t.Fatalf("parseLRC: got %d lines, want 3: %+v", len(lines), lines)
+	}
+
+	want := []LyricLine{
+		{Timestamp: 0, Text: ""},
+		{Timestamp: 12*time.Second + 500*time.Millisecond, Text: "Hello there"},
+		{Timestamp: time.Minute + 2*time.Second + 340*time.Millisecond, Text: "Second line"},
+	}
+
+	for i, line := range lines {
+		if line.Timestamp != want[i].Timestamp || line.Text != want[i].Text {
+			t.Errorf("parseLRC line %d = %+v, want %+v", i, line, want[i])
+		}
+	}
+}
+
+func TestSyncedToPlainText(t *testing.T) {
+	lines := parseLRC("[00:01.00]First\n[00:02.00]Second")
+	got := syncedToPlainText(lines)
+	want := "First\nSecond"
+	if got != want {
+		t.Errorf("syncedToPlainText = %q, want %q", got, want)
+	}
+}
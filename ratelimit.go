@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAfterError wraps a failure that came with an explicit Retry-After
+// hint from the server, so retryOperation can honor it instead of
+// applying its usual jittered backoff.
+type RetryAfterError struct {
+	After time.Duration
+	Err   error
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number
+// of seconds or an HTTP-date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when), true
+	}
+
+	return 0, false
+}
+
+// throttledStatusError builds the error returned for 429/503 responses,
+// wrapping it in a RetryAfterError when the server told us how long to wait.
+func throttledStatusError(resp *http.Response) error {
+	err := fmt.Errorf("rate limited: status %d", resp.StatusCode)
+
+	if after, ok := parseRetryAfter(resp); ok {
+		return &RetryAfterError{After: after, Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RobotsPolicy captures the handful of robots.txt directives this
+// scraper cares about: crawl delay and disallowed paths from the
+// "User-agent: *" group. Groups for named user agents are ignored.
+type RobotsPolicy struct {
+	CrawlDelay time.Duration
+	Disallow   []string
+}
+
+// FetchRobotsPolicy fetches and parses /robots.txt for baseURL. A missing
+// or unparsable robots.txt yields an empty (permissive) policy rather
+// than an error, since its absence does not forbid crawling. A request
+// that fails before any response arrives is returned as an error.
+func FetchRobotsPolicy(ctx context.Context, client *http.Client, baseURL string) (*RobotsPolicy, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return &RobotsPolicy{}, nil
+	}
+	u.Path = "/robots.txt"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return &RobotsPolicy{}, nil
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &RobotsPolicy{}, nil
+	}
+
+	policy := &RobotsPolicy{}
+	relevant := false
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			relevant = value == "*"
+		case "disallow":
+			if relevant && value != "" {
+				policy.Disallow = append(policy.Disallow, value)
+			}
+		case "crawl-delay":
+			if relevant {
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+					policy.CrawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// Allowed reports whether path is permitted by the policy's Disallow rules.
+func (p *RobotsPolicy) Allowed(path string) bool {
+	if p == nil {
+		return true
+	}
+	for _, disallowed := range p.Disallow {
+		if strings.HasPrefix(path, disallowed) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchRobotsPolicy(t *testing.T) {
+	const body = `# comment line
+User-agent: googlebot
+Disallow: /googlebot-only
+
+User-agent: *
+Disallow: /private
+Disallow: /search
+Crawl-delay: 2.5
+`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	policy, err := FetchRobotsPolicy(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("FetchRobotsPolicy: %v", err)
+	}
+
+	if policy.CrawlDelay != 2500*time.Millisecond {
+		t.Errorf("CrawlDelay = %v, want 2.5s", policy.CrawlDelay)
+	}
+
+	if len(policy.Disallow) != 2 || policy.Disallow[0] != "/private" || policy.Disallow[1] != "/search" {
+		t.Errorf("Disallow = %v, want [/private /search] (googlebot-only rules must not apply to us)", policy.Disallow)
+	}
+
+	if policy.Allowed("/googlebot-only/page") == false {
+		t.Error("Allowed(/googlebot-only/page) = false, want true: that rule is scoped to googlebot, not us")
+	}
+	if policy.Allowed("/private/page") {
+		t.Error("Allowed(/private/page) = true, want false")
+	}
+	if !policy.Allowed("/lyrics/some-artist") {
+		t.Error("Allowed(/lyrics/some-artist) = false, want true")
+	}
+}
+
+func TestFetchRobotsPolicyMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	policy, err := FetchRobotsPolicy(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("FetchRobotsPolicy: %v", err)
+	}
+	if policy.CrawlDelay != 0 || len(policy.Disallow) != 0 {
+		t.Errorf("missing robots.txt should yield an empty policy, got %+v", policy)
+	}
+	if !policy.Allowed("/anything") {
+		t.Error("missing robots.txt should be permissive")
+	}
+}